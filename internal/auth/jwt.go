@@ -0,0 +1,51 @@
+// Package auth emite y valida los JWT de sesión, y expone el middleware que
+// protege las rutas de escritura.
+package auth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Expiry es la vigencia de los tokens emitidos por GenerateToken.
+const Expiry = 72 * time.Hour
+
+// GenerateToken firma un JWT HS256 para userID, válido durante Expiry.
+func GenerateToken(secret []byte, userID string) (token string, expiresAt time.Time, err error) {
+	expiresAt = time.Now().Add(Expiry)
+
+	claims := jwt.RegisteredClaims{
+		Subject:   userID,
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("error al firmar el token: %v", err)
+	}
+
+	return signed, expiresAt, nil
+}
+
+// ParseToken valida tokenString contra secret y devuelve el ID de usuario
+// que contiene.
+func ParseToken(secret []byte, tokenString string) (userID string, err error) {
+	var claims jwt.RegisteredClaims
+	token, err := jwt.ParseWithClaims(tokenString, &claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("método de firma inesperado: %v", t.Header["alg"])
+		}
+		return secret, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("token inválido: %v", err)
+	}
+	if !token.Valid {
+		return "", fmt.Errorf("token inválido")
+	}
+
+	return claims.Subject, nil
+}
@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGenerateAndParseToken(t *testing.T) {
+	secret := []byte("test-secret")
+
+	token, expiresAt, err := GenerateToken(secret, "user-123")
+	if err != nil {
+		t.Fatalf("GenerateToken devolvió error: %v", err)
+	}
+	if token == "" {
+		t.Fatal("GenerateToken devolvió un token vacío")
+	}
+	if !expiresAt.After(time.Now()) {
+		t.Fatalf("expiresAt debería estar en el futuro, fue %v", expiresAt)
+	}
+
+	userID, err := ParseToken(secret, token)
+	if err != nil {
+		t.Fatalf("ParseToken devolvió error para un token recién emitido: %v", err)
+	}
+	if userID != "user-123" {
+		t.Fatalf("userID = %q, quería %q", userID, "user-123")
+	}
+}
+
+func TestParseTokenRejectsWrongSecret(t *testing.T) {
+	token, _, err := GenerateToken([]byte("secret-a"), "user-123")
+	if err != nil {
+		t.Fatalf("GenerateToken devolvió error: %v", err)
+	}
+
+	if _, err := ParseToken([]byte("secret-b"), token); err == nil {
+		t.Fatal("ParseToken debería rechazar un token firmado con otro secreto")
+	}
+}
+
+func TestParseTokenRejectsGarbage(t *testing.T) {
+	if _, err := ParseToken([]byte("test-secret"), "no-es-un-jwt"); err == nil {
+		t.Fatal("ParseToken debería rechazar una cadena que no es un JWT")
+	}
+}
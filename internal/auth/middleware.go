@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type contextKey string
+
+const userIDContextKey contextKey = "userID"
+
+// RequireUser exige un header "Authorization: Bearer <token>" válido,
+// firmado con secret, e inyecta el ID del usuario en el contexto de la
+// solicitud. Responde 401 si el token falta o no es válido.
+func RequireUser(secret []byte) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			tokenString, ok := strings.CutPrefix(header, "Bearer ")
+			if !ok || tokenString == "" {
+				http.Error(w, "Se requiere un token de autenticación", http.StatusUnauthorized)
+				return
+			}
+
+			userID, err := ParseToken(secret, tokenString)
+			if err != nil {
+				http.Error(w, "Token inválido o expirado", http.StatusUnauthorized)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), userIDContextKey, userID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// UserIDFromContext recupera el ID de usuario inyectado por RequireUser.
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	userID, ok := ctx.Value(userIDContextKey).(string)
+	return userID, ok
+}
@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequireUserRejectsMissingHeader(t *testing.T) {
+	secret := []byte("test-secret")
+	handler := RequireUser(secret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("el handler protegido no debería llamarse sin token")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/posts", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, quería %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestRequireUserRejectsInvalidToken(t *testing.T) {
+	secret := []byte("test-secret")
+	handler := RequireUser(secret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("el handler protegido no debería llamarse con un token inválido")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/posts", nil)
+	req.Header.Set("Authorization", "Bearer no-es-un-jwt")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, quería %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestRequireUserInjectsUserID(t *testing.T) {
+	secret := []byte("test-secret")
+	token, _, err := GenerateToken(secret, "user-123")
+	if err != nil {
+		t.Fatalf("GenerateToken devolvió error: %v", err)
+	}
+
+	var gotUserID string
+	var gotOK bool
+	handler := RequireUser(secret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUserID, gotOK = UserIDFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/posts", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, quería %d", rec.Code, http.StatusOK)
+	}
+	if !gotOK {
+		t.Fatal("UserIDFromContext no encontró un userID en el contexto")
+	}
+	if gotUserID != "user-123" {
+		t.Fatalf("userID = %q, quería %q", gotUserID, "user-123")
+	}
+}
@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// HashPassword calcula el hash bcrypt de password, listo para almacenar.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("error al generar el hash de la contraseña: %v", err)
+	}
+
+	return string(hash), nil
+}
+
+// ComparePassword devuelve nil si password corresponde al hash almacenado.
+func ComparePassword(hash, password string) error {
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		return fmt.Errorf("credenciales inválidas")
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+// Package config centraliza la carga de las variables de entorno de la
+// aplicación en una sola estructura tipada, leída una única vez al arrancar.
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/caarlos0/env/v10"
+	"github.com/joho/godotenv"
+)
+
+// Config agrupa toda la configuración necesaria para levantar el servidor.
+type Config struct {
+	// DBDriver selecciona el motor de base de datos: "postgres" en
+	// producción, "sqlite3" para pruebas locales sin un servidor Postgres.
+	DBDriver   string `env:"DB_DRIVER" envDefault:"postgres"`
+	DBUser     string `env:"DB_USER"`
+	DBPassword string `env:"DB_PASSWORD"`
+	DBName     string `env:"DB_NAME"`
+	DBHost     string `env:"DB_HOST"`
+	DBPort     string `env:"DB_PORT"`
+	DBTable    string `env:"DB_TABLE,required"`
+	// DBSSLMode es el valor de sslmode usado al conectar con "postgres"
+	// (disable, require, verify-ca, verify-full, etc.).
+	DBSSLMode string `env:"DB_SSLMODE" envDefault:"disable"`
+	// DBPath es la ruta del archivo usada cuando DBDriver es "sqlite3".
+	DBPath string `env:"DB_PATH" envDefault:"./data.db"`
+
+	ServerPort string `env:"SERVER_PORT" envDefault:"3000"`
+
+	// FTSEnabled activa la búsqueda de texto completo de PostgreSQL
+	// (to_tsvector/plainto_tsquery) para el parámetro ?q= de GET /posts.
+	// Si está deshabilitado, o el driver no es "postgres", se usa ILIKE/LIKE.
+	FTSEnabled bool `env:"FTS_ENABLED" envDefault:"true"`
+	// DefaultLimit y MaxLimit acotan el parámetro ?limit= de GET /posts.
+	DefaultLimit int `env:"DEFAULT_LIMIT" envDefault:"20"`
+	MaxLimit     int `env:"MAX_LIMIT" envDefault:"100"`
+
+	// JWTSecret firma los JWT de sesión emitidos en /auth/login (HS256).
+	JWTSecret string `env:"JWT_SECRET,required"`
+
+	// DBMaxOpenConns, DBMaxIdleConns y DBConnMaxLifetime tunean el pool de
+	// conexiones compartido, abierto una sola vez al arrancar.
+	DBMaxOpenConns    int           `env:"DB_MAX_OPEN_CONNS" envDefault:"25"`
+	DBMaxIdleConns    int           `env:"DB_MAX_IDLE_CONNS" envDefault:"25"`
+	DBConnMaxLifetime time.Duration `env:"DB_CONN_MAX_LIFETIME" envDefault:"5m"`
+
+	// ShutdownTimeout es cuánto espera el servidor a que las solicitudes en
+	// curso terminen durante un apagado ordenado.
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" envDefault:"10s"`
+
+	// ImageStorageURL selecciona dónde se guardan las imágenes subidas:
+	// "local://<dir>" para un directorio en disco, o "s3://<bucket>[/<prefix>]".
+	ImageStorageURL string `env:"IMAGE_STORAGE_URL" envDefault:"local://./data"`
+	// ImageMaxUploadBytes acota el tamaño aceptado por POST /posts/{id}/image.
+	ImageMaxUploadBytes int64 `env:"IMAGE_MAX_UPLOAD_BYTES" envDefault:"10485760"`
+}
+
+// Load carga el archivo .env (si existe) y construye el Config a partir de
+// las variables de entorno del proceso.
+func Load() (Config, error) {
+	// Si no hay .env no es un error: puede venir todo de variables de
+	// entorno reales (por ejemplo, en un contenedor).
+	_ = godotenv.Load()
+
+	var cfg Config
+	if err := env.Parse(&cfg); err != nil {
+		return Config{}, fmt.Errorf("error al cargar la configuración: %v", err)
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/SebastianWebApp/GO-Crud-Con-PostgreSQL/internal/auth"
+	"github.com/SebastianWebApp/GO-Crud-Con-PostgreSQL/internal/store"
+)
+
+// credentials es el cuerpo esperado por Register y Login.
+type credentials struct {
+	Email    string `json:"Email"`
+	Password string `json:"Password"`
+}
+
+// loginResponse es la Respuesta de un login exitoso.
+type loginResponse struct {
+	Access  string `json:"Access"`
+	Expires string `json:"Expires"`
+}
+
+// AuthHandler agrupa los handlers de registro e inicio de sesión.
+type AuthHandler struct {
+	users     store.UserRepository
+	jwtSecret []byte
+}
+
+// NewAuthHandler crea un AuthHandler respaldado por users, firmando los
+// tokens emitidos con jwtSecret.
+func NewAuthHandler(users store.UserRepository, jwtSecret []byte) *AuthHandler {
+	return &AuthHandler{users: users, jwtSecret: jwtSecret}
+}
+
+// Register crea una cuenta a partir de {Email, Password}, almacenando la
+// contraseña como un hash bcrypt.
+func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var creds credentials
+	if err := json.NewDecoder(r.Body).Decode(&creds); err != nil || creds.Email == "" || creds.Password == "" {
+		writeJSON(w, http.StatusBadRequest, Response{Estado: false, Respuesta: "Solicitud no válida"})
+		return
+	}
+
+	passwordHash, err := auth.HashPassword(creds.Password)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, Response{
+			Estado:    false,
+			Respuesta: fmt.Sprintf("Error al registrar el usuario: %v", err),
+		})
+		return
+	}
+
+	user := store.User{ID: uuid.NewString(), Email: creds.Email, PasswordHash: passwordHash}
+	if err := h.users.Create(r.Context(), user); err != nil {
+		status := http.StatusInternalServerError
+		if err == store.ErrUserExists {
+			status = http.StatusConflict
+		}
+		writeJSON(w, status, Response{
+			Estado:    false,
+			Respuesta: fmt.Sprintf("Error al registrar el usuario: %v", err),
+		})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, Response{Estado: true, Respuesta: "Usuario registrado exitosamente"})
+}
+
+// Login valida {Email, Password} contra el usuario registrado y, si
+// coinciden, emite un JWT de sesión.
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var creds credentials
+	if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{Estado: false, Respuesta: "Solicitud no válida"})
+		return
+	}
+
+	user, err := h.users.GetByEmail(r.Context(), creds.Email)
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, Response{Estado: false, Respuesta: "Credenciales inválidas"})
+		return
+	}
+
+	if err := auth.ComparePassword(user.PasswordHash, creds.Password); err != nil {
+		writeJSON(w, http.StatusUnauthorized, Response{Estado: false, Respuesta: "Credenciales inválidas"})
+		return
+	}
+
+	token, expires, err := auth.GenerateToken(h.jwtSecret, user.ID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, Response{
+			Estado:    false,
+			Respuesta: fmt.Sprintf("Error al iniciar sesión: %v", err),
+		})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, Response{
+		Estado:    true,
+		Respuesta: loginResponse{Access: token, Expires: expires.Format(http.TimeFormat)},
+	})
+}
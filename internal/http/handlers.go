@@ -0,0 +1,271 @@
+// Package http contiene los handlers REST de la API y el router que los
+// expone, con el PostRepository inyectado una sola vez por construcción.
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/SebastianWebApp/GO-Crud-Con-PostgreSQL/internal/auth"
+	"github.com/SebastianWebApp/GO-Crud-Con-PostgreSQL/internal/store"
+)
+
+// Response es la envoltura estándar de toda respuesta de la API.
+type Response struct {
+	Estado    bool        `json:"Estado"`
+	Respuesta interface{} `json:"Respuesta"` // Cambiar a `interface{}` para poder manejar cualquier tipo de dato
+}
+
+// ListEnvelope es la Respuesta de GET /posts: la página de resultados junto
+// con los datos necesarios para pedir la siguiente.
+type ListEnvelope struct {
+	Items  []store.Post `json:"Items"`
+	Total  int          `json:"Total"`
+	Limit  int          `json:"Limit"`
+	Offset int          `json:"Offset"`
+}
+
+// PostHandler agrupa los handlers HTTP relacionados con los posts, con el
+// repositorio inyectado a través de su constructor.
+type PostHandler struct {
+	repo         store.PostRepository
+	defaultLimit int
+	maxLimit     int
+}
+
+// NewPostHandler crea un PostHandler respaldado por repo. defaultLimit y
+// maxLimit acotan el parámetro ?limit= de List.
+func NewPostHandler(repo store.PostRepository, defaultLimit, maxLimit int) *PostHandler {
+	return &PostHandler{repo: repo, defaultLimit: defaultLimit, maxLimit: maxLimit}
+}
+
+func writeJSON(w http.ResponseWriter, status int, resp Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// writeStoreError traduce un error del store en la respuesta HTTP adecuada:
+// 403 si el solicitante no es el dueño del post, 500 en cualquier otro caso.
+func writeStoreError(w http.ResponseWriter, action string, err error) {
+	status := http.StatusInternalServerError
+	if err == store.ErrForbidden {
+		status = http.StatusForbidden
+	}
+	writeJSON(w, status, Response{Estado: false, Respuesta: fmt.Sprintf("%s: %v", action, err)})
+}
+
+// Create crea un post, asociado al usuario autenticado, a partir del cuerpo
+// JSON de la solicitud.
+func (h *PostHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var post store.Post
+	if err := json.NewDecoder(r.Body).Decode(&post); err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{Estado: false, Respuesta: "Solicitud no válida"})
+		return
+	}
+
+	ownerID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, Response{Estado: false, Respuesta: "Se requiere autenticación"})
+		return
+	}
+	post.OwnerID = ownerID
+
+	if err := h.repo.Create(r.Context(), post); err != nil {
+		writeJSON(w, http.StatusInternalServerError, Response{
+			Estado:    false,
+			Respuesta: fmt.Sprintf("Error al guardar la información: %v", err),
+		})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, Response{Estado: true, Respuesta: "Información guardado exitosamente"})
+}
+
+// List devuelve una página de posts, opcionalmente filtrada por ?q= y
+// ordenada por ?order=, a partir de ?limit= y ?offset=.
+func (h *PostHandler) List(w http.ResponseWriter, r *http.Request) {
+	params := store.ListParams{
+		Limit:  h.parseLimit(r),
+		Offset: parseNonNegativeInt(r.URL.Query().Get("offset"), 0),
+		Query:  r.URL.Query().Get("q"),
+		Order:  r.URL.Query().Get("order"),
+	}
+
+	posts, total, err := h.repo.List(r.Context(), params)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, Response{
+			Estado:    false,
+			Respuesta: fmt.Sprintf("Error al obtener la información: %v", err),
+		})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, Response{
+		Estado: true,
+		Respuesta: ListEnvelope{
+			Items:  posts,
+			Total:  total,
+			Limit:  params.Limit,
+			Offset: params.Offset,
+		},
+	})
+}
+
+// parseLimit lee ?limit= y lo acota a [1, h.maxLimit], usando h.defaultLimit
+// cuando no se indica o es inválido.
+func (h *PostHandler) parseLimit(r *http.Request) int {
+	limit := parseNonNegativeInt(r.URL.Query().Get("limit"), h.defaultLimit)
+	if limit <= 0 {
+		limit = h.defaultLimit
+	}
+	if limit > h.maxLimit {
+		limit = h.maxLimit
+	}
+	return limit
+}
+
+// parseNonNegativeInt interpreta raw como un entero no negativo, devolviendo
+// fallback si está vacío o no es válido.
+func parseNonNegativeInt(raw string, fallback int) int {
+	if raw == "" {
+		return fallback
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 0 {
+		return fallback
+	}
+	return value
+}
+
+// Get obtiene un post por el {id} de la ruta.
+func (h *PostHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	post, err := h.repo.Get(r.Context(), id)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, Response{
+			Estado:    false,
+			Respuesta: fmt.Sprintf("Error al obtener el ID: %v", err),
+		})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, Response{Estado: true, Respuesta: post})
+}
+
+// Update actualiza el post identificado por {id} con el cuerpo JSON
+// recibido, exigiendo que el usuario autenticado sea su dueño.
+func (h *PostHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var post store.Post
+	if err := json.NewDecoder(r.Body).Decode(&post); err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{Estado: false, Respuesta: "Solicitud no válida"})
+		return
+	}
+	post.ID = chi.URLParam(r, "id")
+
+	ownerID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, Response{Estado: false, Respuesta: "Se requiere autenticación"})
+		return
+	}
+	post.OwnerID = ownerID
+
+	if err := h.repo.Update(r.Context(), post); err != nil {
+		writeStoreError(w, "Error al actualizar la información", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, Response{Estado: true, Respuesta: "Información actualizado exitosamente"})
+}
+
+// Delete elimina el post identificado por {id}, exigiendo que el usuario
+// autenticado sea su dueño.
+func (h *PostHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	ownerID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, Response{Estado: false, Respuesta: "Se requiere autenticación"})
+		return
+	}
+
+	if err := h.repo.Delete(r.Context(), id, ownerID); err != nil {
+		writeStoreError(w, "Error al eliminar la información", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, Response{Estado: true, Respuesta: "Información eliminado exitosamente"})
+}
+
+// legacyGetByID conserva el contrato de /posts_uni: recibe el ID en el
+// cuerpo JSON en lugar de en la ruta.
+func (h *PostHandler) legacyGetByID(w http.ResponseWriter, r *http.Request) {
+	var post store.Post
+	if err := json.NewDecoder(r.Body).Decode(&post); err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{Estado: false, Respuesta: "Solicitud no válida"})
+		return
+	}
+
+	found, err := h.repo.Get(r.Context(), post.ID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, Response{
+			Estado:    false,
+			Respuesta: fmt.Sprintf("Error al obtener el ID: %v", err),
+		})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, Response{Estado: true, Respuesta: found})
+}
+
+// legacyUpdate conserva el contrato de /update: recibe el post completo,
+// incluyendo el ID, en el cuerpo JSON en lugar de en la ruta.
+func (h *PostHandler) legacyUpdate(w http.ResponseWriter, r *http.Request) {
+	var post store.Post
+	if err := json.NewDecoder(r.Body).Decode(&post); err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{Estado: false, Respuesta: "Solicitud no válida"})
+		return
+	}
+
+	ownerID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, Response{Estado: false, Respuesta: "Se requiere autenticación"})
+		return
+	}
+	post.OwnerID = ownerID
+
+	if err := h.repo.Update(r.Context(), post); err != nil {
+		writeStoreError(w, "Error al actualizar la información", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, Response{Estado: true, Respuesta: "Información actualizado exitosamente"})
+}
+
+// legacyDelete conserva el contrato de /delete: recibe el ID en el cuerpo
+// JSON en lugar de en la ruta.
+func (h *PostHandler) legacyDelete(w http.ResponseWriter, r *http.Request) {
+	var post store.Post
+	if err := json.NewDecoder(r.Body).Decode(&post); err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{Estado: false, Respuesta: "Solicitud no válida"})
+		return
+	}
+
+	ownerID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, Response{Estado: false, Respuesta: "Se requiere autenticación"})
+		return
+	}
+
+	if err := h.repo.Delete(r.Context(), post.ID, ownerID); err != nil {
+		writeStoreError(w, "Error al eliminar la información", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, Response{Estado: true, Respuesta: "Información eliminado exitosamente"})
+}
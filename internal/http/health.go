@@ -0,0 +1,42 @@
+package http
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+)
+
+// pingTimeout acota cuánto espera /readyz a que la base de datos responda.
+const pingTimeout = 2 * time.Second
+
+// HealthHandler agrupa los endpoints de liveness y readiness.
+type HealthHandler struct {
+	db *sql.DB
+}
+
+// NewHealthHandler crea un HealthHandler que usa db para comprobar
+// readiness.
+func NewHealthHandler(db *sql.DB) *HealthHandler {
+	return &HealthHandler{db: db}
+}
+
+// Healthz es la sonda de liveness: responde mientras el proceso esté vivo,
+// sin depender de recursos externos.
+func (h *HealthHandler) Healthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, Response{Estado: true, Respuesta: "ok"})
+}
+
+// Readyz es la sonda de readiness: responde 503 si la base de datos no
+// contesta dentro de pingTimeout.
+func (h *HealthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), pingTimeout)
+	defer cancel()
+
+	if err := h.db.PingContext(ctx); err != nil {
+		writeJSON(w, http.StatusServiceUnavailable, Response{Estado: false, Respuesta: "la base de datos no responde"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, Response{Estado: true, Respuesta: "ok"})
+}
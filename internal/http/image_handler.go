@@ -0,0 +1,138 @@
+package http
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/SebastianWebApp/GO-Crud-Con-PostgreSQL/internal/auth"
+	"github.com/SebastianWebApp/GO-Crud-Con-PostgreSQL/internal/storage"
+	"github.com/SebastianWebApp/GO-Crud-Con-PostgreSQL/internal/store"
+)
+
+// imageCacheControl marca las imágenes como cacheables de forma permanente:
+// al ser direccionables por contenido, su hash cambia si el contenido cambia.
+const imageCacheControl = "public, max-age=31536000, immutable"
+
+// ImageHandler sube y sirve las imágenes asociadas a un post, almacenadas
+// de forma direccionable por contenido en un storage.Backend.
+type ImageHandler struct {
+	posts          store.PostRepository
+	backend        storage.Backend
+	maxUploadBytes int64
+}
+
+// NewImageHandler crea un ImageHandler respaldado por posts y backend.
+// maxUploadBytes acota el tamaño del cuerpo aceptado por Upload.
+func NewImageHandler(posts store.PostRepository, backend storage.Backend, maxUploadBytes int64) *ImageHandler {
+	return &ImageHandler{posts: posts, backend: backend, maxUploadBytes: maxUploadBytes}
+}
+
+// Upload recibe un multipart/form-data con un campo "image", lo guarda de
+// forma direccionable por contenido, y actualiza Post.Imagen del post
+// identificado por {id} con la URL resultante. Exige que el usuario
+// autenticado sea el dueño del post.
+func (h *ImageHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	ownerID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, Response{Estado: false, Respuesta: "Se requiere autenticación"})
+		return
+	}
+
+	post, err := h.posts.Get(r.Context(), id)
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, Response{
+			Estado:    false,
+			Respuesta: fmt.Sprintf("Error al obtener el post: %v", err),
+		})
+		return
+	}
+	if post.OwnerID != ownerID {
+		writeJSON(w, http.StatusForbidden, Response{Estado: false, Respuesta: "No autorizado para modificar este post"})
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxUploadBytes)
+	if err := r.ParseMultipartForm(h.maxUploadBytes); err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{Estado: false, Respuesta: "La imagen supera el tamaño máximo permitido"})
+		return
+	}
+
+	file, header, err := r.FormFile("image")
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{Estado: false, Respuesta: "Se requiere el campo \"image\""})
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{Estado: false, Respuesta: "Error al leer la imagen"})
+		return
+	}
+
+	sniffed := http.DetectContentType(content)
+	if declared := header.Header.Get("Content-Type"); declared != "" && declared != sniffed {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Estado:    false,
+			Respuesta: fmt.Sprintf("El Content-Type declarado (%s) no coincide con el contenido (%s)", declared, sniffed),
+		})
+		return
+	}
+
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+
+	url, err := h.backend.Put(r.Context(), hash, content)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, Response{
+			Estado:    false,
+			Respuesta: fmt.Sprintf("Error al guardar la imagen: %v", err),
+		})
+		return
+	}
+
+	post.Imagen = url
+	post.OwnerID = ownerID
+	if err := h.posts.Update(r.Context(), post); err != nil {
+		writeStoreError(w, "Error al actualizar el post con la nueva imagen", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, Response{Estado: true, Respuesta: post})
+}
+
+// Serve sirve el archivo almacenado bajo {hash}.
+func (h *ImageHandler) Serve(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+	if !storage.ValidHash(hash) {
+		writeJSON(w, http.StatusBadRequest, Response{Estado: false, Respuesta: "Hash de imagen inválido"})
+		return
+	}
+
+	rc, err := h.backend.Open(r.Context(), hash)
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, Response{Estado: false, Respuesta: "Imagen no encontrada"})
+		return
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(rc)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, Response{Estado: false, Respuesta: "Error al leer la imagen"})
+		return
+	}
+
+	w.Header().Set("Content-Type", http.DetectContentType(content))
+	w.Header().Set("Cache-Control", imageCacheControl)
+	w.Header().Set("ETag", `"`+hash+`"`)
+	http.ServeContent(w, r, hash, time.Time{}, bytes.NewReader(content))
+}
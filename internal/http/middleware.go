@@ -0,0 +1,85 @@
+package http
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type requestIDContextKey struct{}
+
+// RequestIDHeader es el header usado para propagar y devolver el ID de
+// correlación de cada solicitud.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID asigna un UUID a cada solicitud entrante que no traiga ya uno en
+// el header X-Request-ID, lo devuelve en la respuesta y lo deja disponible
+// en el contexto para el resto de middlewares y handlers.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if id == "" {
+			id = uuid.NewString()
+		}
+		w.Header().Set(RequestIDHeader, id)
+
+		ctx := context.WithValue(r.Context(), requestIDContextKey{}, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequestIDFromContext recupera el ID de correlación inyectado por RequestID.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDContextKey{}).(string)
+	return id, ok
+}
+
+// statusRecorder envuelve un http.ResponseWriter para capturar el código de
+// estado y los bytes escritos, necesarios para el log de acceso.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (rec *statusRecorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *statusRecorder) Write(b []byte) (int, error) {
+	n, err := rec.ResponseWriter.Write(b)
+	rec.bytes += n
+	return n, err
+}
+
+// AccessLog registra cada solicitud con los campos del formato combinado de
+// Apache (host remoto, método, ruta, protocolo, estado, tamaño, referer y
+// user-agent), emitidos como JSON estructurado a través de logger.
+func AccessLog(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(rec, r)
+
+			requestID, _ := RequestIDFromContext(r.Context())
+			logger.Info("request",
+				"request_id", requestID,
+				"remote_addr", r.RemoteAddr,
+				"method", r.Method,
+				"path", r.URL.RequestURI(),
+				"proto", r.Proto,
+				"status", rec.status,
+				"bytes", rec.bytes,
+				"referer", r.Referer(),
+				"user_agent", r.UserAgent(),
+				"latency_ms", time.Since(start).Milliseconds(),
+			)
+		})
+	}
+}
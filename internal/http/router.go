@@ -0,0 +1,73 @@
+package http
+
+import (
+	"database/sql"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/SebastianWebApp/GO-Crud-Con-PostgreSQL/internal/auth"
+	"github.com/SebastianWebApp/GO-Crud-Con-PostgreSQL/internal/storage"
+	"github.com/SebastianWebApp/GO-Crud-Con-PostgreSQL/internal/store"
+)
+
+// RouterConfig reúne los parámetros necesarios para armar el router:
+// paginación de GET /posts, el secreto que firma los JWT de sesión, y los
+// límites de subida de imágenes.
+type RouterConfig struct {
+	DefaultLimit        int
+	MaxLimit            int
+	JWTSecret           []byte
+	ImageMaxUploadBytes int64
+}
+
+// NewRouter arma el router de la aplicación: rutas RESTful bajo /posts,
+// autenticación bajo /auth, subida y servido de imágenes, sondas de salud,
+// y los alias heredados (/notify, /posts_uni, /update, /delete) que se
+// mantienen por compatibilidad con clientes existentes. Las operaciones de
+// escritura sobre posts exigen un JWT válido. Cada solicitud recibe un ID
+// de correlación y se registra con logger en formato de log de acceso.
+func NewRouter(postRepo store.PostRepository, userRepo store.UserRepository, images storage.Backend, db *sql.DB, logger *slog.Logger, cfg RouterConfig) http.Handler {
+	h := NewPostHandler(postRepo, cfg.DefaultLimit, cfg.MaxLimit)
+	authHandler := NewAuthHandler(userRepo, cfg.JWTSecret)
+	imageHandler := NewImageHandler(postRepo, images, cfg.ImageMaxUploadBytes)
+	health := NewHealthHandler(db)
+	requireUser := auth.RequireUser(cfg.JWTSecret)
+
+	r := chi.NewRouter()
+	r.Use(RequestID)
+	r.Use(AccessLog(logger))
+
+	r.Get("/healthz", health.Healthz)
+	r.Get("/readyz", health.Readyz)
+
+	r.Route("/auth", func(r chi.Router) {
+		r.Post("/register", authHandler.Register)
+		r.Post("/login", authHandler.Login)
+	})
+
+	r.Route("/posts", func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Get("/{id}", h.Get)
+
+		r.Group(func(r chi.Router) {
+			r.Use(requireUser)
+			r.Post("/", h.Create)
+			r.Put("/{id}", h.Update)
+			r.Delete("/{id}", h.Delete)
+			r.Post("/{id}/image", imageHandler.Upload)
+		})
+	})
+
+	r.Get("/images/{hash}", imageHandler.Serve)
+
+	// Alias heredados, deprecados en favor de /posts. Las escrituras
+	// también exigen autenticación.
+	r.With(requireUser).Post("/notify", h.Create)
+	r.Post("/posts_uni", h.legacyGetByID)
+	r.With(requireUser).Post("/update", h.legacyUpdate)
+	r.With(requireUser).Post("/delete", h.legacyDelete)
+
+	return r
+}
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// LocalBackend guarda los archivos como un directorio en disco, uno por
+// hash, sin subcarpetas adicionales.
+type LocalBackend struct {
+	baseDir string
+}
+
+// NewLocalBackend crea un LocalBackend respaldado por baseDir. No toca el
+// disco: Put crea baseDir, si no existe, al guardar el primer archivo.
+func NewLocalBackend(baseDir string) *LocalBackend {
+	return &LocalBackend{baseDir: baseDir}
+}
+
+func (b *LocalBackend) path(hash string) (string, error) {
+	if !ValidHash(hash) {
+		return "", ErrInvalidHash
+	}
+	return filepath.Join(b.baseDir, hash), nil
+}
+
+// Put escribe content bajo hash, sin reescribirlo si ya existe, y devuelve
+// la URL con la que GET /images/{hash} lo sirve.
+func (b *LocalBackend) Put(ctx context.Context, hash string, content []byte) (string, error) {
+	path, err := b.path(hash)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(b.baseDir, 0o755); err != nil {
+		return "", fmt.Errorf("error al preparar el almacenamiento de imágenes: %v", err)
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		return publicURL(hash), nil
+	}
+
+	tmp, err := os.CreateTemp(b.baseDir, hash+".tmp-*")
+	if err != nil {
+		return "", fmt.Errorf("error al guardar la imagen: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("error al guardar la imagen: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("error al guardar la imagen: %v", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", fmt.Errorf("error al guardar la imagen: %v", err)
+	}
+
+	return publicURL(hash), nil
+}
+
+// Open lee el archivo almacenado bajo hash.
+func (b *LocalBackend) Open(ctx context.Context, hash string) (io.ReadCloser, error) {
+	path, err := b.path(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer la imagen: %v", err)
+	}
+	return f, nil
+}
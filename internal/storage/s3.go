@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// S3Backend guarda los archivos como objetos de un bucket S3, bajo prefix
+// si se indica uno.
+type S3Backend struct {
+	client *s3.Client
+	bucket string
+	prefix string
+}
+
+// NewS3Backend crea un S3Backend para bucket, cargando las credenciales y la
+// región desde el entorno igual que cualquier otro cliente del SDK de AWS.
+func NewS3Backend(bucket, prefix string) (*S3Backend, error) {
+	if bucket == "" {
+		return nil, errors.New("IMAGE_STORAGE_URL de tipo s3:// debe indicar un bucket")
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error al cargar la configuración de AWS: %v", err)
+	}
+
+	return &S3Backend{client: s3.NewFromConfig(cfg), bucket: bucket, prefix: prefix}, nil
+}
+
+func (b *S3Backend) key(hash string) (string, error) {
+	if !ValidHash(hash) {
+		return "", ErrInvalidHash
+	}
+	if b.prefix == "" {
+		return hash, nil
+	}
+	return path.Join(b.prefix, hash), nil
+}
+
+// Put sube content bajo hash, sin reescribirlo si el objeto ya existe, y
+// devuelve la URL con la que GET /images/{hash} lo sirve.
+func (b *S3Backend) Put(ctx context.Context, hash string, content []byte) (string, error) {
+	key, err := b.key(hash)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = b.client.HeadObject(ctx, &s3.HeadObjectInput{Bucket: aws.String(b.bucket), Key: aws.String(key)})
+	if err == nil {
+		return publicURL(hash), nil
+	}
+
+	_, err = b.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(content),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error al subir la imagen a S3: %v", err)
+	}
+
+	return publicURL(hash), nil
+}
+
+// Open descarga el objeto almacenado bajo hash.
+func (b *S3Backend) Open(ctx context.Context, hash string) (io.ReadCloser, error) {
+	key, err := b.key(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := b.client.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String(b.bucket), Key: aws.String(key)})
+	if err != nil {
+		var notFound *types.NoSuchKey
+		if errors.As(err, &notFound) {
+			return nil, fmt.Errorf("la imagen no existe: %v", err)
+		}
+		return nil, fmt.Errorf("error al leer la imagen de S3: %v", err)
+	}
+
+	return out.Body, nil
+}
@@ -0,0 +1,69 @@
+// Package storage guarda los archivos de imagen subidos por los posts de
+// forma direccionable por contenido: la clave de cada archivo es el hash de
+// sus bytes, así que subir el mismo contenido dos veces reutiliza el mismo
+// objeto.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidHash indica que un hash no tiene la forma de un SHA-256 en
+// hexadecimal, y por lo tanto no corresponde a un objeto direccionable por
+// contenido válido.
+var ErrInvalidHash = errors.New("hash inválido")
+
+var hashPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// ValidHash indica si hash tiene la forma de un SHA-256 en hexadecimal. Los
+// backends la exigen antes de usar hash para construir una ruta o clave, ya
+// que viene de un parámetro de la URL y no debe tratarse como de confianza.
+func ValidHash(hash string) bool {
+	return hashPattern.MatchString(hash)
+}
+
+// Backend persiste y sirve archivos identificados por su hash SHA-256 en
+// hexadecimal.
+type Backend interface {
+	// Put guarda content bajo hash y devuelve la URL pública con la que se
+	// sirve. Si hash ya existe, no vuelve a escribirlo.
+	Put(ctx context.Context, hash string, content []byte) (publicURL string, err error)
+	// Open devuelve el contenido almacenado bajo hash.
+	Open(ctx context.Context, hash string) (io.ReadCloser, error)
+}
+
+// NewBackend construye el Backend descrito por rawURL: "local://<dir>" para
+// un directorio en disco, o "s3://<bucket>[/<prefix>]" para un bucket S3.
+func NewBackend(rawURL string) (Backend, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("error al interpretar IMAGE_STORAGE_URL: %v", err)
+	}
+
+	switch u.Scheme {
+	case "local":
+		dir := u.Host + u.Path
+		if dir == "" {
+			dir = "."
+		}
+		return NewLocalBackend(dir), nil
+	case "s3":
+		bucket := u.Host
+		prefix := strings.TrimPrefix(u.Path, "/")
+		return NewS3Backend(bucket, prefix)
+	default:
+		return nil, fmt.Errorf("esquema de almacenamiento no soportado: %s", u.Scheme)
+	}
+}
+
+// publicURL es la ruta con la que el servidor expone el archivo bajo hash,
+// sin importar el backend que lo respalde.
+func publicURL(hash string) string {
+	return "/images/" + hash
+}
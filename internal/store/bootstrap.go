@@ -0,0 +1,68 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/SebastianWebApp/GO-Crud-Con-PostgreSQL/internal/config"
+)
+
+// Connect abre, una única vez, el pool de conexiones compartido hacia la
+// base de datos indicada en cfg, usando el driver que BuildDSN resuelva a
+// partir de cfg.DBDriver. El pool se ajusta con los límites de cfg para que
+// nada vuelva a abrir una conexión por solicitud.
+func Connect(cfg config.Config) (*sql.DB, error) {
+	driver, dsn, err := BuildDSN(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("error al conectar a la base de datos: %v", err)
+	}
+
+	db.SetMaxOpenConns(cfg.DBMaxOpenConns)
+	db.SetMaxIdleConns(cfg.DBMaxIdleConns)
+	db.SetConnMaxLifetime(cfg.DBConnMaxLifetime)
+
+	return db, nil
+}
+
+// CreateDatabaseIfNotExists verifica si la base de datos existe, y la crea
+// si no es así. Solo aplica al driver "postgres": sqlite3 crea el archivo
+// de datos automáticamente al conectarse.
+func CreateDatabaseIfNotExists(cfg config.Config) error {
+	if cfg.DBDriver != "" && cfg.DBDriver != "postgres" {
+		return nil
+	}
+
+	db, err := sql.Open("postgres", postgresDSN(cfg, ""))
+	if err != nil {
+		return fmt.Errorf("error al conectar a la base de datos: %v", err)
+	}
+	defer db.Close()
+
+	var exists bool
+	query := `SELECT 1 FROM pg_database WHERE datname = $1`
+	err = db.QueryRow(query, cfg.DBName).Scan(&exists)
+	if err != nil && err.Error() != "sql: no rows in result set" {
+		return fmt.Errorf("error al verificar si la base de datos existe: %v", err)
+	}
+
+	if exists {
+		fmt.Printf("La base de datos '%s' ya existe.\n", cfg.DBName)
+		return nil
+	}
+
+	createQuery := fmt.Sprintf("CREATE DATABASE %s", cfg.DBName)
+	if _, err = db.Exec(createQuery); err != nil {
+		return fmt.Errorf("error al crear la base de datos: %v", err)
+	}
+
+	fmt.Printf("Base de datos '%s' creada exitosamente.\n", cfg.DBName)
+	return nil
+}
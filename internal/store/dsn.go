@@ -0,0 +1,40 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/SebastianWebApp/GO-Crud-Con-PostgreSQL/internal/config"
+)
+
+// BuildDSN construye el driver y la cadena de conexión a partir de cfg,
+// de modo que Connect y las migraciones puedan ser independientes del
+// motor de base de datos elegido.
+func BuildDSN(cfg config.Config) (driver string, dsn string, err error) {
+	switch cfg.DBDriver {
+	case "", "postgres":
+		return "postgres", postgresDSN(cfg, cfg.DBName), nil
+	case "sqlite3":
+		return "sqlite3", cfg.DBPath, nil
+	default:
+		return "", "", fmt.Errorf("driver de base de datos no soportado: %s", cfg.DBDriver)
+	}
+}
+
+// postgresDSN arma la cadena de conexión de postgres a partir de cfg,
+// respetando cfg.DBSSLMode. dbName queda fuera de la cadena si va vacío,
+// para conectar al servidor sin apuntar a una base en particular (por
+// ejemplo, al verificar o crear la base de datos en CreateDatabaseIfNotExists).
+func postgresDSN(cfg config.Config, dbName string) string {
+	sslMode := cfg.DBSSLMode
+	if sslMode == "" {
+		sslMode = "disable"
+	}
+
+	if dbName == "" {
+		return fmt.Sprintf("user=%s password=%s host=%s port=%s sslmode=%s",
+			cfg.DBUser, cfg.DBPassword, cfg.DBHost, cfg.DBPort, sslMode)
+	}
+
+	return fmt.Sprintf("user=%s password=%s dbname=%s host=%s port=%s sslmode=%s",
+		cfg.DBUser, cfg.DBPassword, dbName, cfg.DBHost, cfg.DBPort, sslMode)
+}
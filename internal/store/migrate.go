@@ -0,0 +1,151 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed migrations/postgres/*.sql
+var postgresMigrations embed.FS
+
+//go:embed migrations/sqlite3/*.sql
+var sqlite3Migrations embed.FS
+
+// migration es un archivo .sql embebido ya asociado a su número de versión.
+type migration struct {
+	version int
+	name    string
+	sql     string
+}
+
+// migrationsFor devuelve las migraciones disponibles para driver, ordenadas
+// por número de versión.
+func migrationsFor(driver string) ([]migration, error) {
+	var dir string
+	var files embed.FS
+	switch driver {
+	case "", "postgres":
+		dir, files = "migrations/postgres", postgresMigrations
+	case "sqlite3":
+		dir, files = "migrations/sqlite3", sqlite3Migrations
+	default:
+		return nil, fmt.Errorf("no hay migraciones para el driver: %s", driver)
+	}
+
+	entries, err := fs.ReadDir(files, dir)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer las migraciones: %v", err)
+	}
+
+	migrations := make([]migration, 0, len(entries))
+	for _, entry := range entries {
+		version, name, ok := parseMigrationFilename(entry.Name())
+		if !ok {
+			continue
+		}
+
+		content, err := fs.ReadFile(files, dir+"/"+entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("error al leer la migración %s: %v", entry.Name(), err)
+		}
+
+		migrations = append(migrations, migration{version: version, name: name, sql: string(content)})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].version < migrations[j].version })
+
+	return migrations, nil
+}
+
+// parseMigrationFilename extrae el número de versión de un nombre del tipo
+// "0002_add_metadata_columns.sql".
+func parseMigrationFilename(filename string) (version int, name string, ok bool) {
+	base := strings.TrimSuffix(filename, ".sql")
+	parts := strings.SplitN(base, "_", 2)
+	if len(parts) != 2 {
+		return 0, "", false
+	}
+
+	version, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", false
+	}
+
+	return version, parts[1], true
+}
+
+// Migrate aplica, en orden y dentro de una transacción cada una, las
+// migraciones pendientes para tableName sobre driver. El progreso se
+// registra en la tabla schema_migrations para que cada migración se
+// ejecute una única vez.
+func Migrate(ctx context.Context, db *sql.DB, driver, tableName string) error {
+	if tableName == "" {
+		return fmt.Errorf("el nombre de la tabla no está definido en las variables de entorno")
+	}
+
+	if _, err := db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL
+		)`); err != nil {
+		return fmt.Errorf("error al crear schema_migrations: %v", err)
+	}
+
+	applied := make(map[int]bool)
+	rows, err := db.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return fmt.Errorf("error al leer schema_migrations: %v", err)
+	}
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			rows.Close()
+			return fmt.Errorf("error al leer schema_migrations: %v", err)
+		}
+		applied[version] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error al leer schema_migrations: %v", err)
+	}
+
+	migrations, err := migrationsFor(driver)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if applied[m.version] {
+			continue
+		}
+
+		statement := strings.ReplaceAll(m.sql, "{{TABLE}}", tableName)
+
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("error al iniciar la transacción de migración %d: %v", m.version, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("error al aplicar la migración %d_%s: %v", m.version, m.name, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", m.version, m.name); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("error al registrar la migración %d_%s: %v", m.version, m.name, err)
+		}
+
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("error al confirmar la migración %d_%s: %v", m.version, m.name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestParseMigrationFilename(t *testing.T) {
+	tests := []struct {
+		filename    string
+		wantVersion int
+		wantName    string
+		wantOK      bool
+	}{
+		{"0001_init.sql", 1, "init", true},
+		{"0005_post_owner.sql", 5, "post_owner", true},
+		{"sin_version.sql", 0, "", false},
+		{"0001.sql", 0, "", false},
+	}
+
+	for _, tt := range tests {
+		version, name, ok := parseMigrationFilename(tt.filename)
+		if ok != tt.wantOK || version != tt.wantVersion || name != tt.wantName {
+			t.Errorf("parseMigrationFilename(%q) = (%d, %q, %v), quería (%d, %q, %v)",
+				tt.filename, version, name, ok, tt.wantVersion, tt.wantName, tt.wantOK)
+		}
+	}
+}
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("error al abrir la base de datos de prueba: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestMigrateAppliesSchemaMigrations(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	if err := Migrate(ctx, db, "sqlite3", "posts"); err != nil {
+		t.Fatalf("Migrate devolvió error: %v", err)
+	}
+
+	var count int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM schema_migrations").Scan(&count); err != nil {
+		t.Fatalf("error al leer schema_migrations: %v", err)
+	}
+	if count == 0 {
+		t.Fatal("schema_migrations está vacía tras Migrate")
+	}
+
+	// La columna OwnerID de la migración 0005 debe existir.
+	if _, err := db.ExecContext(ctx, "INSERT INTO posts (ID, OwnerID) VALUES ($1, $2)", "1", "owner-1"); err != nil {
+		t.Fatalf("no se pudo insertar en posts tras aplicar las migraciones: %v", err)
+	}
+}
+
+func TestMigrateIsIdempotent(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	if err := Migrate(ctx, db, "sqlite3", "posts"); err != nil {
+		t.Fatalf("primera llamada a Migrate devolvió error: %v", err)
+	}
+	if err := Migrate(ctx, db, "sqlite3", "posts"); err != nil {
+		t.Fatalf("segunda llamada a Migrate devolvió error: %v", err)
+	}
+
+	var count int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM schema_migrations").Scan(&count); err != nil {
+		t.Fatalf("error al leer schema_migrations: %v", err)
+	}
+
+	migrations, err := migrationsFor("sqlite3")
+	if err != nil {
+		t.Fatalf("migrationsFor devolvió error: %v", err)
+	}
+	if count != len(migrations) {
+		t.Fatalf("schema_migrations tiene %d filas, quería %d", count, len(migrations))
+	}
+}
+
+func TestMigrateRequiresTableName(t *testing.T) {
+	db := openTestDB(t)
+
+	if err := Migrate(context.Background(), db, "sqlite3", ""); err == nil {
+		t.Fatal("Migrate debería fallar sin un nombre de tabla")
+	}
+}
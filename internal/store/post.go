@@ -0,0 +1,286 @@
+// Package store contiene el modelo Post y el acceso a la base de datos
+// a través de la interfaz PostRepository.
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Post representa una publicación almacenada en la base de datos.
+type Post struct {
+	ID          string    `json:"ID"`
+	Imagen      string    `json:"Imagen"`
+	Nombre      string    `json:"Nombre"`
+	Descripcion string    `json:"Descripcion"`
+	Tags        []string  `json:"Tags"`
+	OwnerID     string    `json:"OwnerID"`
+	CreatedAt   time.Time `json:"CreatedAt"`
+	UpdatedAt   time.Time `json:"UpdatedAt"`
+}
+
+// ErrForbidden se devuelve al intentar modificar o eliminar un post que no
+// pertenece al OwnerID solicitante.
+var ErrForbidden = fmt.Errorf("no autorizado para modificar esta información")
+
+// ListParams acota y filtra el resultado de List: paginación, búsqueda por
+// texto (q) y orden.
+type ListParams struct {
+	Limit  int
+	Offset int
+	Query  string
+	// Order es "columna" o "columna desc"; columna debe pertenecer a
+	// orderableColumns. Vacío equivale al orden por defecto.
+	Order string
+}
+
+// PostRepository define las operaciones disponibles sobre los posts,
+// independientemente del motor de base de datos que las resuelva.
+type PostRepository interface {
+	Create(ctx context.Context, post Post) error
+	Get(ctx context.Context, id string) (Post, error)
+	// List devuelve la página de posts descrita por params y el total de
+	// posts que cumplen el filtro, sin paginar.
+	List(ctx context.Context, params ListParams) (posts []Post, total int, err error)
+	// Update aplica los cambios de post, exigiendo que post.OwnerID sea el
+	// dueño actual del registro; devuelve ErrForbidden en caso contrario.
+	Update(ctx context.Context, post Post) error
+	// Delete elimina el post id, exigiendo que ownerID sea su dueño actual;
+	// devuelve ErrForbidden en caso contrario.
+	Delete(ctx context.Context, id, ownerID string) error
+}
+
+// sqlRepository implementa PostRepository sobre una tabla SQL cuyo nombre
+// se fija una sola vez en el constructor. Soporta tanto PostgreSQL como
+// sqlite3, ya que la serialización de Tags y la búsqueda de texto difieren
+// entre ambos.
+type sqlRepository struct {
+	db         *sql.DB
+	driver     string
+	tableName  string
+	ftsEnabled bool
+}
+
+// NewPostgresRepository crea un PostRepository respaldado por db, operando
+// sobre tableName, con la búsqueda de texto completo habilitada.
+func NewPostgresRepository(db *sql.DB, tableName string) PostRepository {
+	return &sqlRepository{db: db, driver: "postgres", tableName: tableName, ftsEnabled: true}
+}
+
+// NewRepository crea un PostRepository respaldado por db para el driver
+// indicado ("postgres" o "sqlite3"). ftsEnabled solo tiene efecto con
+// driver "postgres"; en cualquier otro caso la búsqueda usa ILIKE/LIKE.
+func NewRepository(db *sql.DB, driver, tableName string, ftsEnabled bool) PostRepository {
+	return &sqlRepository{db: db, driver: driver, tableName: tableName, ftsEnabled: ftsEnabled}
+}
+
+// orderableColumns mapea los nombres de columna aceptados en ?order=
+// (en minúsculas) a su nombre real de columna, para evitar inyección SQL
+// a través de ese parámetro.
+var orderableColumns = map[string]string{
+	"id":          "ID",
+	"nombre":      "Nombre",
+	"descripcion": "Descripcion",
+	"createdat":   "CreatedAt",
+	"updatedat":   "UpdatedAt",
+}
+
+// defaultOrder es el ORDER BY usado cuando ListParams.Order está vacío o no
+// es reconocido.
+const defaultOrder = "CreatedAt DESC"
+
+// buildOrderBy valida y normaliza ListParams.Order a una cláusula ORDER BY
+// segura para concatenar directamente en la consulta.
+func buildOrderBy(order string) string {
+	fields := strings.Fields(order)
+	if len(fields) == 0 || len(fields) > 2 {
+		return defaultOrder
+	}
+
+	column, ok := orderableColumns[strings.ToLower(fields[0])]
+	if !ok {
+		return defaultOrder
+	}
+
+	direction := "ASC"
+	if len(fields) == 2 && strings.EqualFold(fields[1], "desc") {
+		direction = "DESC"
+	}
+
+	return fmt.Sprintf("%s %s", column, direction)
+}
+
+// tagsOut prepara post.Tags para pasarlo como parámetro de una consulta.
+func (r *sqlRepository) tagsOut(tags []string) interface{} {
+	if r.driver == "sqlite3" {
+		return strings.Join(tags, ",")
+	}
+	return pq.Array(tags)
+}
+
+// tagsIn prepara un destino de Scan para la columna Tags.
+func (r *sqlRepository) tagsIn(post *Post) interface{} {
+	if r.driver == "sqlite3" {
+		return &sqliteTags{post: post}
+	}
+	return pq.Array(&post.Tags)
+}
+
+// sqliteTags adapta la columna Tags, almacenada como texto separado por
+// comas en sqlite3, al campo []string de Post.
+type sqliteTags struct {
+	post *Post
+}
+
+func (t *sqliteTags) Scan(src interface{}) error {
+	var raw string
+	switch v := src.(type) {
+	case nil:
+		raw = ""
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	default:
+		return fmt.Errorf("tipo inesperado para Tags: %T", src)
+	}
+
+	if raw == "" {
+		t.post.Tags = nil
+		return nil
+	}
+	t.post.Tags = strings.Split(raw, ",")
+	return nil
+}
+
+// Create guarda un nuevo post en la base de datos.
+func (r *sqlRepository) Create(ctx context.Context, post Post) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (ID, Imagen, Nombre, Descripcion, Tags, OwnerID, CreatedAt, UpdatedAt)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)`, r.tableName)
+
+	now := time.Now().UTC()
+	_, err := r.db.ExecContext(ctx, query, post.ID, post.Imagen, post.Nombre, post.Descripcion, r.tagsOut(post.Tags), post.OwnerID, now)
+	if err != nil {
+		return fmt.Errorf("error al guardar el post: %v", err)
+	}
+
+	return nil
+}
+
+// Get obtiene un post por su ID.
+func (r *sqlRepository) Get(ctx context.Context, id string) (Post, error) {
+	if id == "" {
+		return Post{}, fmt.Errorf("el ID de la información no puede estar vacío")
+	}
+
+	query := fmt.Sprintf("SELECT ID, Imagen, Nombre, Descripcion, Tags, OwnerID, CreatedAt, UpdatedAt FROM %s WHERE ID = $1", r.tableName)
+
+	var post Post
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&post.ID, &post.Imagen, &post.Nombre, &post.Descripcion, r.tagsIn(&post), &post.OwnerID, &post.CreatedAt, &post.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Post{}, fmt.Errorf("no se encontró una información con el ID proporcionado")
+		}
+		return Post{}, fmt.Errorf("error al obtener el post: %v", err)
+	}
+
+	return post, nil
+}
+
+// List devuelve la página de posts descrita por params, junto con el total
+// de posts que cumplen el filtro de búsqueda (sin paginar).
+func (r *sqlRepository) List(ctx context.Context, params ListParams) ([]Post, int, error) {
+	where := ""
+	args := []interface{}{}
+
+	if params.Query != "" {
+		if r.driver == "postgres" && r.ftsEnabled {
+			where = "WHERE to_tsvector('spanish', Nombre || ' ' || Descripcion) @@ plainto_tsquery($1)"
+			args = append(args, params.Query)
+		} else {
+			like := "ILIKE"
+			if r.driver == "sqlite3" {
+				like = "LIKE"
+			}
+			where = fmt.Sprintf("WHERE Nombre %s $1 OR Descripcion %s $1", like, like)
+			args = append(args, "%"+params.Query+"%")
+		}
+	}
+
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s %s", r.tableName, where)
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("error al contar los posts: %v", err)
+	}
+
+	limitArg := len(args) + 1
+	offsetArg := len(args) + 2
+	query := fmt.Sprintf(
+		"SELECT ID, Imagen, Nombre, Descripcion, Tags, OwnerID, CreatedAt, UpdatedAt FROM %s %s ORDER BY %s LIMIT $%d OFFSET $%d",
+		r.tableName, where, buildOrderBy(params.Order), limitArg, offsetArg,
+	)
+	args = append(args, params.Limit, params.Offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error al obtener los posts: %v", err)
+	}
+	defer rows.Close()
+
+	var posts []Post
+	for rows.Next() {
+		var post Post
+		if err := rows.Scan(&post.ID, &post.Imagen, &post.Nombre, &post.Descripcion, r.tagsIn(&post), &post.OwnerID, &post.CreatedAt, &post.UpdatedAt); err != nil {
+			return nil, 0, fmt.Errorf("error al escanear el post: %v", err)
+		}
+		posts = append(posts, post)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error al iterar sobre los posts: %v", err)
+	}
+
+	return posts, total, nil
+}
+
+// Update actualiza un post existente, siempre que post.OwnerID sea su dueño.
+func (r *sqlRepository) Update(ctx context.Context, post Post) error {
+	query := fmt.Sprintf("UPDATE %s SET Imagen = $1, Nombre = $2, Descripcion = $3, Tags = $4, UpdatedAt = $5 WHERE ID = $6 AND OwnerID = $7", r.tableName)
+
+	result, err := r.db.ExecContext(ctx, query, post.Imagen, post.Nombre, post.Descripcion, r.tagsOut(post.Tags), time.Now().UTC(), post.ID, post.OwnerID)
+	if err != nil {
+		return fmt.Errorf("error al actualizar el post: %v", err)
+	}
+
+	return r.requireRowAffected(result)
+}
+
+// Delete elimina un post por su ID, siempre que ownerID sea su dueño.
+func (r *sqlRepository) Delete(ctx context.Context, id, ownerID string) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE ID = $1 AND OwnerID = $2", r.tableName)
+
+	result, err := r.db.ExecContext(ctx, query, id, ownerID)
+	if err != nil {
+		return fmt.Errorf("error al eliminar el post: %v", err)
+	}
+
+	return r.requireRowAffected(result)
+}
+
+// requireRowAffected traduce "cero filas afectadas" en ErrForbidden: o bien
+// el post no existe, o bien existe pero pertenece a otro usuario.
+func (r *sqlRepository) requireRowAffected(result sql.Result) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al verificar el resultado de la operación: %v", err)
+	}
+	if rows == 0 {
+		return ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func newTestRepository(t *testing.T) PostRepository {
+	t.Helper()
+	db := openTestDB(t)
+	if err := Migrate(context.Background(), db, "sqlite3", "posts"); err != nil {
+		t.Fatalf("error al aplicar las migraciones de prueba: %v", err)
+	}
+	return NewRepository(db, "sqlite3", "posts", false)
+}
+
+func TestUpdateRejectsNonOwner(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	post := Post{ID: "1", Nombre: "original", OwnerID: "owner-1"}
+	if err := repo.Create(ctx, post); err != nil {
+		t.Fatalf("Create devolvió error: %v", err)
+	}
+
+	post.Nombre = "modificado por otro"
+	post.OwnerID = "owner-2"
+	err := repo.Update(ctx, post)
+	if !errors.Is(err, ErrForbidden) {
+		t.Fatalf("Update con OwnerID ajeno = %v, quería ErrForbidden", err)
+	}
+
+	got, err := repo.Get(ctx, "1")
+	if err != nil {
+		t.Fatalf("Get devolvió error: %v", err)
+	}
+	if got.Nombre != "original" {
+		t.Fatalf("Nombre = %q, el post no debería haber cambiado", got.Nombre)
+	}
+}
+
+func TestUpdateAllowsOwner(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	post := Post{ID: "1", Nombre: "original", OwnerID: "owner-1"}
+	if err := repo.Create(ctx, post); err != nil {
+		t.Fatalf("Create devolvió error: %v", err)
+	}
+
+	post.Nombre = "modificado por su dueño"
+	if err := repo.Update(ctx, post); err != nil {
+		t.Fatalf("Update con OwnerID correcto devolvió error: %v", err)
+	}
+
+	got, err := repo.Get(ctx, "1")
+	if err != nil {
+		t.Fatalf("Get devolvió error: %v", err)
+	}
+	if got.Nombre != "modificado por su dueño" {
+		t.Fatalf("Nombre = %q, el post debería haberse actualizado", got.Nombre)
+	}
+}
+
+func TestDeleteRejectsNonOwner(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	post := Post{ID: "1", Nombre: "original", OwnerID: "owner-1"}
+	if err := repo.Create(ctx, post); err != nil {
+		t.Fatalf("Create devolvió error: %v", err)
+	}
+
+	err := repo.Delete(ctx, "1", "owner-2")
+	if !errors.Is(err, ErrForbidden) {
+		t.Fatalf("Delete con ownerID ajeno = %v, quería ErrForbidden", err)
+	}
+
+	if _, err := repo.Get(ctx, "1"); err != nil {
+		t.Fatalf("el post debería seguir existiendo: %v", err)
+	}
+}
+
+func TestDeleteAllowsOwner(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	post := Post{ID: "1", Nombre: "original", OwnerID: "owner-1"}
+	if err := repo.Create(ctx, post); err != nil {
+		t.Fatalf("Create devolvió error: %v", err)
+	}
+
+	if err := repo.Delete(ctx, "1", "owner-1"); err != nil {
+		t.Fatalf("Delete con ownerID correcto devolvió error: %v", err)
+	}
+
+	if _, err := repo.Get(ctx, "1"); err == nil {
+		t.Fatal("el post debería haberse eliminado")
+	}
+}
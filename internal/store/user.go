@@ -0,0 +1,77 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// User representa una cuenta registrada, autenticada por email y contraseña.
+type User struct {
+	ID           string    `json:"ID"`
+	Email        string    `json:"Email"`
+	PasswordHash string    `json:"-"`
+	CreatedAt    time.Time `json:"CreatedAt"`
+}
+
+// ErrUserExists se devuelve al intentar registrar un email ya existente.
+var ErrUserExists = fmt.Errorf("ya existe un usuario con ese email")
+
+// ErrUserNotFound se devuelve cuando no hay ningún usuario con el email
+// solicitado.
+var ErrUserNotFound = fmt.Errorf("no se encontró un usuario con ese email")
+
+// UserRepository define las operaciones disponibles sobre los usuarios.
+type UserRepository interface {
+	Create(ctx context.Context, user User) error
+	GetByEmail(ctx context.Context, email string) (User, error)
+}
+
+// sqlUserRepository implementa UserRepository sobre la tabla fija "users".
+type sqlUserRepository struct {
+	db *sql.DB
+}
+
+// NewUserRepository crea un UserRepository respaldado por db.
+func NewUserRepository(db *sql.DB) UserRepository {
+	return &sqlUserRepository{db: db}
+}
+
+// Create registra un nuevo usuario.
+func (r *sqlUserRepository) Create(ctx context.Context, user User) error {
+	_, err := r.db.ExecContext(ctx,
+		"INSERT INTO users (ID, Email, PasswordHash, CreatedAt) VALUES ($1, $2, $3, $4)",
+		user.ID, user.Email, user.PasswordHash, time.Now().UTC())
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrUserExists
+		}
+		return fmt.Errorf("error al registrar el usuario: %v", err)
+	}
+
+	return nil
+}
+
+// GetByEmail busca un usuario por su email.
+func (r *sqlUserRepository) GetByEmail(ctx context.Context, email string) (User, error) {
+	var user User
+	err := r.db.QueryRowContext(ctx, "SELECT ID, Email, PasswordHash, CreatedAt FROM users WHERE Email = $1", email).
+		Scan(&user.ID, &user.Email, &user.PasswordHash, &user.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return User{}, ErrUserNotFound
+		}
+		return User{}, fmt.Errorf("error al obtener el usuario: %v", err)
+	}
+
+	return user, nil
+}
+
+// isUniqueViolation reconoce, de forma aproximada y sin importar el driver
+// SQL subyacente, un error de violación de restricción UNIQUE.
+func isUniqueViolation(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "unique") || strings.Contains(msg, "duplicate key")
+}